@@ -1,6 +1,11 @@
 package tfcomponents
 
 import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
 	"github.com/hashicorp/terraform/internal/terraform-ng/internal/ngaddrs"
 	"github.com/hashicorp/terraform/internal/tfdiags"
 )
@@ -14,3 +19,29 @@ type Component struct {
 func (c *Component) CallAddr() ngaddrs.ComponentCall {
 	return ngaddrs.ComponentCall{Name: c.Name}
 }
+
+// componentSchema is the body schema for an individual "component" block,
+// used once the block has already been identified by rootSchema.
+var componentSchema = &hcl.BodySchema{}
+
+func decodeComponentBlock(block *hcl.Block) (*Component, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	name := block.Labels[0]
+	if !hclsyntax.ValidIdentifier(name) {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid component name",
+			Detail:   fmt.Sprintf("Component name %q is not a valid identifier.", name),
+			Subject:  block.LabelRanges[0].Ptr(),
+		})
+	}
+
+	_, hclDiags := block.Body.Content(componentSchema)
+	diags = diags.Append(hclDiags)
+
+	return &Component{
+		Name:      name,
+		DeclRange: tfdiags.SourceRangeFromHCL(block.DefRange),
+	}, diags
+}
@@ -1,6 +1,12 @@
 package tfcomponents
 
 import (
+	"fmt"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/hashicorp/terraform/internal/terraform-ng/internal/ngaddrs"
 	"github.com/hashicorp/terraform/internal/tfdiags"
 )
@@ -8,9 +14,103 @@ import (
 type ComponentGroup struct {
 	Name string
 
+	// SourceAddr is the source address given in the "source" argument, in
+	// the same address syntax Terraform uses for module source addresses:
+	// a local relative path, or a address that github.com/hashicorp/go-getter
+	// knows how to fetch, such as a git, HTTPS, S3, or GCS URL. It's empty
+	// if the block has no "source" argument, in which case the group's
+	// contents must be loaded some other way.
+	SourceAddr      string
+	SourceAddrRange tfdiags.SourceRange
+
+	// Version is an optional version constraint given in the "version"
+	// argument, used to select among the versions available at
+	// SourceAddr for source address types that support versioning.
+	Version      string
+	VersionRange tfdiags.SourceRange
+
+	// Dir is the local directory holding the resolved contents of
+	// SourceAddr, populated by an Installer's Install method. It's empty
+	// until installation has completed.
+	Dir string
+
+	// Config is the child configuration loaded from Dir once Install has
+	// completed. It's nil until then.
+	Config *Config
+
 	DeclRange tfdiags.SourceRange
 }
 
 func (c *ComponentGroup) CallAddr() ngaddrs.ComponentGroupCall {
 	return ngaddrs.ComponentGroupCall{Name: c.Name}
 }
+
+// componentGroupSchema is the body schema for an individual
+// "component_group" block, used once the block has already been
+// identified by rootSchema.
+var componentGroupSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "source", Required: false},
+		{Name: "version", Required: false},
+	},
+}
+
+func decodeComponentGroupBlock(block *hcl.Block) (*ComponentGroup, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	name := block.Labels[0]
+	if !hclsyntax.ValidIdentifier(name) {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid component group name",
+			Detail:   fmt.Sprintf("Component group name %q is not a valid identifier.", name),
+			Subject:  block.LabelRanges[0].Ptr(),
+		})
+	}
+
+	content, hclDiags := block.Body.Content(componentGroupSchema)
+	diags = diags.Append(hclDiags)
+
+	ret := &ComponentGroup{
+		Name:      name,
+		DeclRange: tfdiags.SourceRangeFromHCL(block.DefRange),
+	}
+
+	if attr, exists := content.Attributes["source"]; exists {
+		val, hclDiags := attr.Expr.Value(nil)
+		diags = diags.Append(hclDiags)
+		ret.SourceAddrRange = tfdiags.SourceRangeFromHCL(attr.Expr.Range())
+		if !hclDiags.HasErrors() {
+			if val.Type() != cty.String || val.IsNull() {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid source address",
+					Detail:   "The source argument requires a string value.",
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			} else {
+				ret.SourceAddr = val.AsString()
+			}
+		}
+	}
+
+	if attr, exists := content.Attributes["version"]; exists {
+		val, hclDiags := attr.Expr.Value(nil)
+		diags = diags.Append(hclDiags)
+		ret.VersionRange = tfdiags.SourceRangeFromHCL(attr.Expr.Range())
+		if !hclDiags.HasErrors() {
+			if val.Type() != cty.String || val.IsNull() {
+				diags = diags.Append(&hcl.Diagnostic{
+					Severity: hcl.DiagError,
+					Summary:  "Invalid version constraint",
+					Detail:   "The version argument requires a string value.",
+					Subject:  attr.Expr.Range().Ptr(),
+				})
+			} else {
+				ret.Version = val.AsString()
+			}
+		}
+	}
+
+	return ret, diags
+}
@@ -1,7 +1,14 @@
 package componentstree
 
 import (
+	"fmt"
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+
 	"github.com/hashicorp/terraform/internal/terraform-ng/internal/ngaddrs"
+	"github.com/hashicorp/terraform/internal/terraform-ng/internal/tfcomponents"
+	"github.com/hashicorp/terraform/internal/tfdiags"
 )
 
 // Node represents a single node in a components tree. Each node corresponds
@@ -18,4 +25,129 @@ type Node struct {
 	// CallPath is the sequence of static component group calls leading to
 	// this node. For the root node in a tree, this has length zero.
 	CallPath []ngaddrs.ComponentGroupCall
+
+	// Config is the configuration associated with this node: the root
+	// Config passed to Build for the root node, or the Config returned by
+	// a GroupLoader for a node reached through a component_group call.
+	//
+	// For a non-root node, Config is exactly what the GroupLoader returned
+	// for this node's call, even if it also returned errors. Where that is
+	// nil, Children is also nil.
+	Config *tfcomponents.Config
+
+	// Children are this node's immediate child nodes, keyed by the name
+	// of the component_group call that reaches each one.
+	Children map[string]*Node
+}
+
+// GroupLoader is called by Build once for each component_group call it
+// encounters, to obtain the configuration describing that group's
+// contents. It isn't called for a call that Build rejects as a cycle. A typical implementation resolves the call's source address
+// (for example using an Installer) and then loads the result with
+// LoadConfigDir.
+type GroupLoader func(ngaddrs.ComponentGroupCall) (*tfcomponents.Config, tfdiags.Diagnostics)
+
+// Build constructs a components tree rooted at root, recursively resolving
+// every component_group call it finds using loadGroup, and returns the
+// tree's root Node.
+//
+// Build detects call cycles by tracking the resolved identity (source
+// address and version) of every component_group call made so far on the
+// path down to the node about to be built, and refusing to recurse into a
+// call whose identity already appears there. Group names are just local
+// labels chosen independently by each configuration, so two unrelated
+// groups in different branches of the tree may legitimately share a name;
+// only a call that resolves back to a source it's already inside counts
+// as a cycle.
+func Build(root *tfcomponents.Config, loadGroup GroupLoader) (*Node, tfdiags.Diagnostics) {
+	rootNode := &Node{
+		Config: root,
+	}
+	rootNode.Root = rootNode
+
+	diags := buildChildren(rootNode, nil, loadGroup)
+	return rootNode, diags
+}
+
+func buildChildren(node *Node, ancestorSources []string, loadGroup GroupLoader) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	if node.Config == nil {
+		return diags
+	}
+
+	names := make([]string, 0, len(node.Config.Groups))
+	for name := range node.Config.Groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	node.Children = make(map[string]*Node, len(names))
+	for _, name := range names {
+		group := node.Config.Groups[name]
+		call := group.CallAddr()
+		source := groupSourceKey(group)
+
+		if sourcesContain(ancestorSources, source) {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Component group call cycle",
+				Detail: fmt.Sprintf(
+					"Component group %q (directly or indirectly) calls its own source, which is not allowed.",
+					call.Name,
+				),
+				Subject: group.DeclRange.ToHCL().Ptr(),
+			})
+			continue
+		}
+
+		childConfig, moreDiags := loadGroup(call)
+		diags = diags.Append(moreDiags)
+
+		childPath := make([]ngaddrs.ComponentGroupCall, len(node.CallPath), len(node.CallPath)+1)
+		copy(childPath, node.CallPath)
+		childPath = append(childPath, call)
+
+		child := &Node{
+			Parent:   node,
+			Root:     node.Root,
+			CallPath: childPath,
+			Config:   childConfig,
+		}
+		node.Children[name] = child
+
+		if childConfig != nil {
+			childSources := make([]string, len(ancestorSources), len(ancestorSources)+1)
+			copy(childSources, ancestorSources)
+			childSources = append(childSources, source)
+			diags = diags.Append(buildChildren(child, childSources, loadGroup))
+		}
+	}
+
+	return diags
+}
+
+// groupSourceKey returns a string identifying what a component_group call
+// actually resolves to, for use in cycle detection. Unlike the call's
+// Name, which is just a label local to whichever configuration declared
+// it, this combines the group's source address and version so that two
+// unrelated groups that happen to share a name are never mistaken for one
+// calling back into itself.
+func groupSourceKey(group *tfcomponents.ComponentGroup) string {
+	if group.SourceAddr == "" {
+		// A group with no source address can't collide with any other
+		// group's source, so we key it on its own identity instead of
+		// treating every sourceless group as equivalent.
+		return fmt.Sprintf("no-source:%p", group)
+	}
+	return group.SourceAddr + "@" + group.Version
+}
+
+func sourcesContain(sources []string, source string) bool {
+	for _, seen := range sources {
+		if seen == source {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,95 @@
+package componentstree
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/internal/terraform-ng/internal/ngaddrs"
+	"github.com/hashicorp/terraform/internal/terraform-ng/internal/tfcomponents"
+	"github.com/hashicorp/terraform/internal/tfdiags"
+)
+
+// TestBuildSharedNameDifferentBranches verifies that two unrelated
+// component_group blocks that happen to share a local name in different
+// branches of the tree aren't mistaken for a call cycle.
+func TestBuildSharedNameDifferentBranches(t *testing.T) {
+	networkA := &tfcomponents.Config{Groups: map[string]*tfcomponents.ComponentGroup{}}
+	networkB := &tfcomponents.Config{Groups: map[string]*tfcomponents.ComponentGroup{}}
+
+	configA := &tfcomponents.Config{
+		Groups: map[string]*tfcomponents.ComponentGroup{
+			"network": {Name: "network", SourceAddr: "./network-a"},
+		},
+	}
+	configB := &tfcomponents.Config{
+		Groups: map[string]*tfcomponents.ComponentGroup{
+			"network": {Name: "network", SourceAddr: "./network-b"},
+		},
+	}
+	root := &tfcomponents.Config{
+		Groups: map[string]*tfcomponents.ComponentGroup{
+			"a": {Name: "a", SourceAddr: "./a"},
+			"b": {Name: "b", SourceAddr: "./b"},
+		},
+	}
+
+	var networkCalls int
+	loadGroup := func(call ngaddrs.ComponentGroupCall) (*tfcomponents.Config, tfdiags.Diagnostics) {
+		switch call.Name {
+		case "a":
+			return configA, nil
+		case "b":
+			return configB, nil
+		case "network":
+			networkCalls++
+			if networkCalls == 1 {
+				return networkA, nil
+			}
+			return networkB, nil
+		default:
+			t.Fatalf("unexpected call to %q", call.Name)
+			return nil, nil
+		}
+	}
+
+	node, diags := Build(root, loadGroup)
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+
+	if got := node.Children["a"].Children["network"]; got == nil {
+		t.Errorf("node a's \"network\" child wasn't built")
+	}
+	if got := node.Children["b"].Children["network"]; got == nil {
+		t.Errorf("node b's \"network\" child wasn't built")
+	}
+}
+
+// TestBuildCycle verifies that a component_group call that resolves back
+// to a source already on its own call path is reported as a cycle,
+// instead of recursing forever.
+func TestBuildCycle(t *testing.T) {
+	selfGroup := &tfcomponents.ComponentGroup{Name: "self", SourceAddr: "./self"}
+	configSelf := &tfcomponents.Config{
+		Groups: map[string]*tfcomponents.ComponentGroup{"self": selfGroup},
+	}
+	root := &tfcomponents.Config{
+		Groups: map[string]*tfcomponents.ComponentGroup{"self": selfGroup},
+	}
+
+	var calls int
+	loadGroup := func(call ngaddrs.ComponentGroupCall) (*tfcomponents.Config, tfdiags.Diagnostics) {
+		calls++
+		return configSelf, nil
+	}
+
+	node, diags := Build(root, loadGroup)
+	if !diags.HasErrors() {
+		t.Fatalf("expected a cycle error, got none")
+	}
+	if got, want := calls, 1; got != want {
+		t.Errorf("loadGroup called %d times, want %d", got, want)
+	}
+	if got := node.Children["self"].Children["self"]; got != nil {
+		t.Errorf("cyclic call was built as a child node, but shouldn't have been")
+	}
+}
@@ -2,10 +2,13 @@ package tfcomponents
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/bmatcuk/doublestar/v4"
 	hcl "github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 
@@ -17,6 +20,13 @@ import (
 // been subjected to decoding and simple static validation but not yet
 // evaluated to produce a full tree with component groups and individual
 // component instances.
+//
+// A Config returned by LoadConfigDir or LoadConfigFS is instead the result
+// of merging every matching file under a directory, in which case
+// Filenames lists the individual files that were merged to produce it.
+// Filename is additionally set to that root directory by LoadConfigDir,
+// but is left empty by LoadConfigFS, since an fs.FS has no inherent root
+// path of its own for Filename to describe.
 type Config struct {
 	Components map[string]*Component
 	Groups     map[string]*ComponentGroup
@@ -25,7 +35,8 @@ type Config struct {
 	LocalValues    map[string]*configs.Local
 	OutputValues   map[string]*configs.Output
 
-	Filename string
+	Filename  string
+	Filenames []string
 }
 
 func LoadConfigFile(filename string) (*Config, tfdiags.Diagnostics) {
@@ -57,17 +68,60 @@ func LoadConfig(filename string, src []byte) (*Config, tfdiags.Diagnostics) {
 	diags = diags.Append(hclDiags)
 
 	ret := &Config{
-		Filename: filepath.ToSlash(filepath.Clean(filename)),
+		Components:     make(map[string]*Component),
+		Groups:         make(map[string]*ComponentGroup),
+		InputVariables: make(map[string]*configs.Variable),
+		LocalValues:    make(map[string]*configs.Local),
+		OutputValues:   make(map[string]*configs.Output),
+		Filename:       filepath.ToSlash(filepath.Clean(filename)),
 	}
 
 	content, hclDiags := f.Body.Content(rootSchema)
+	diags = diags.Append(hclDiags)
 	for _, block := range content.Blocks {
 		switch block.Type {
 		case "component":
+			component, moreDiags := decodeComponentBlock(block)
+			diags = diags.Append(moreDiags)
+			if existing, exists := ret.Components[component.Name]; exists {
+				diags = diags.Append(duplicateDeclDiag("component", component.Name, existing.DeclRange, component.DeclRange))
+				continue
+			}
+			ret.Components[component.Name] = component
 		case "component_group":
+			group, moreDiags := decodeComponentGroupBlock(block)
+			diags = diags.Append(moreDiags)
+			if existing, exists := ret.Groups[group.Name]; exists {
+				diags = diags.Append(duplicateDeclDiag("component_group", group.Name, existing.DeclRange, group.DeclRange))
+				continue
+			}
+			ret.Groups[group.Name] = group
 		case "variable":
+			v, hclDiags := decodeVariableBlock(block)
+			diags = diags.Append(hclDiags)
+			if existing, exists := ret.InputVariables[v.Name]; exists {
+				diags = diags.Append(duplicateDeclDiag("variable", v.Name, tfdiags.SourceRangeFromHCL(existing.DeclRange), tfdiags.SourceRangeFromHCL(v.DeclRange)))
+				continue
+			}
+			ret.InputVariables[v.Name] = v
 		case "locals":
+			locals, hclDiags := decodeLocalsBlock(block)
+			diags = diags.Append(hclDiags)
+			for _, local := range locals {
+				if existing, exists := ret.LocalValues[local.Name]; exists {
+					diags = diags.Append(duplicateDeclDiag("local value", local.Name, tfdiags.SourceRangeFromHCL(existing.DeclRange), tfdiags.SourceRangeFromHCL(local.DeclRange)))
+					continue
+				}
+				ret.LocalValues[local.Name] = local
+			}
 		case "output":
+			o, hclDiags := decodeOutputBlock(block)
+			diags = diags.Append(hclDiags)
+			if existing, exists := ret.OutputValues[o.Name]; exists {
+				diags = diags.Append(duplicateDeclDiag("output", o.Name, tfdiags.SourceRangeFromHCL(existing.DeclRange), tfdiags.SourceRangeFromHCL(o.DeclRange)))
+				continue
+			}
+			ret.OutputValues[o.Name] = o
 		default:
 			// If we get here then it's a bug either in our schema or in HCL.
 			panic(fmt.Sprintf("unexpected block type %q", block.Type))
@@ -77,6 +131,161 @@ func LoadConfig(filename string, src []byte) (*Config, tfdiags.Diagnostics) {
 	return ret, diags
 }
 
+// LoadConfigDir walks the directory tree rooted at dir, loading and merging
+// every ".tfcomponents.hcl" file whose path (relative to dir) matches at
+// least one of the given patterns.
+//
+// Patterns are matched using github.com/bmatcuk/doublestar, so callers can
+// write patterns such as "**/*.tfcomponents.hcl" to match at any depth, or
+// "modules/**/prod.tfcomponents.hcl" to match a narrower subset of the
+// tree. Any path excluded by a ".terraformignore" file in dir is skipped
+// regardless of whether it matches a pattern.
+//
+// See LoadConfigFS for details on how the matched files are merged and how
+// collisions between their declarations are reported.
+func LoadConfigDir(dir string, patterns []string) (*Config, tfdiags.Diagnostics) {
+	config, diags := LoadConfigFS(os.DirFS(dir), patterns)
+	if config != nil {
+		config.Filename = filepath.ToSlash(filepath.Clean(dir))
+	}
+	return config, diags
+}
+
+// LoadConfigFS is the fs.FS-based equivalent of LoadConfigDir, for callers
+// that already have files in memory or otherwise don't want to load
+// directly from the real filesystem.
+//
+// The files matched by patterns are loaded with LoadConfig and merged
+// together into a single *Config, in lexical order of their paths within
+// fsys. If two files declare a component, component group, variable, local
+// value, or output of the same name, that's reported as an error in the
+// returned diagnostics, blaming the source locations of both declarations.
+func LoadConfigFS(fsys fs.FS, patterns []string) (*Config, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+
+	ignore, moreDiags := loadIgnoreRules(fsys)
+	diags = diags.Append(moreDiags)
+
+	var filenames []string
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		matches, err := doublestar.Glob(fsys, pattern)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Invalid components configuration pattern",
+				fmt.Sprintf("Pattern %q is not a valid doublestar glob: %s.", pattern, err),
+			))
+			continue
+		}
+		for _, match := range matches {
+			if !strings.HasSuffix(match, ".tfcomponents.hcl") {
+				continue
+			}
+			if ignore.match(match) {
+				continue
+			}
+			if seen[match] {
+				continue
+			}
+			seen[match] = true
+			filenames = append(filenames, match)
+		}
+	}
+	sort.Strings(filenames)
+
+	ret := &Config{
+		Components:     make(map[string]*Component),
+		Groups:         make(map[string]*ComponentGroup),
+		InputVariables: make(map[string]*configs.Variable),
+		LocalValues:    make(map[string]*configs.Local),
+		OutputValues:   make(map[string]*configs.Output),
+		Filenames:      filenames,
+	}
+	for _, filename := range filenames {
+		src, err := fs.ReadFile(fsys, filename)
+		if err != nil {
+			diags = diags.Append(tfdiags.Sourceless(
+				tfdiags.Error,
+				"Can't open configuration file",
+				fmt.Sprintf("Error while loading %s: %s.", filename, err),
+			))
+			continue
+		}
+		fileConfig, fileDiags := LoadConfig(filename, src)
+		diags = diags.Append(fileDiags)
+		if fileConfig == nil {
+			continue
+		}
+		diags = diags.Append(mergeConfig(ret, fileConfig))
+	}
+
+	return ret, diags
+}
+
+// mergeConfig merges the declarations in src into dst, which is mutated in
+// place. It returns diagnostics describing any name collisions it finds;
+// the first declaration of a colliding name wins and is left in dst.
+func mergeConfig(dst, src *Config) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	for name, c := range src.Components {
+		if existing, exists := dst.Components[name]; exists {
+			diags = diags.Append(duplicateDeclDiag("component", name, existing.DeclRange, c.DeclRange))
+			continue
+		}
+		dst.Components[name] = c
+	}
+	for name, g := range src.Groups {
+		if existing, exists := dst.Groups[name]; exists {
+			diags = diags.Append(duplicateDeclDiag("component_group", name, existing.DeclRange, g.DeclRange))
+			continue
+		}
+		dst.Groups[name] = g
+	}
+	for name, v := range src.InputVariables {
+		if existing, exists := dst.InputVariables[name]; exists {
+			diags = diags.Append(duplicateDeclDiag("variable", name, tfdiags.SourceRangeFromHCL(existing.DeclRange), tfdiags.SourceRangeFromHCL(v.DeclRange)))
+			continue
+		}
+		dst.InputVariables[name] = v
+	}
+	for name, l := range src.LocalValues {
+		if existing, exists := dst.LocalValues[name]; exists {
+			diags = diags.Append(duplicateDeclDiag("local value", name, tfdiags.SourceRangeFromHCL(existing.DeclRange), tfdiags.SourceRangeFromHCL(l.DeclRange)))
+			continue
+		}
+		dst.LocalValues[name] = l
+	}
+	for name, o := range src.OutputValues {
+		if existing, exists := dst.OutputValues[name]; exists {
+			diags = diags.Append(duplicateDeclDiag("output", name, tfdiags.SourceRangeFromHCL(existing.DeclRange), tfdiags.SourceRangeFromHCL(o.DeclRange)))
+			continue
+		}
+		dst.OutputValues[name] = o
+	}
+
+	return diags
+}
+
+func duplicateDeclDiag(kind, name string, first, second tfdiags.SourceRange) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+	diags = diags.Append(&hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  fmt.Sprintf("Duplicate %s name", kind),
+		Detail: fmt.Sprintf(
+			"A %s named %q was already declared at %s.",
+			kind, name, formatSourceRange(first),
+		),
+		Subject: second.ToHCL().Ptr(),
+	})
+	return diags
+}
+
+func formatSourceRange(rng tfdiags.SourceRange) string {
+	return fmt.Sprintf("%s:%d,%d", rng.Filename, rng.Start.Line, rng.Start.Column)
+}
+
 var rootSchema = &hcl.BodySchema{
 	Blocks: []hcl.BlockHeaderSchema{
 		{Type: "component", LabelNames: []string{"name"}},
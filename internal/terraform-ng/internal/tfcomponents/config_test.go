@@ -0,0 +1,39 @@
+package tfcomponents
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadConfigFSMergeDistinctFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.tfcomponents.hcl": &fstest.MapFile{Data: []byte("component \"foo\" {}\n")},
+		"b.tfcomponents.hcl": &fstest.MapFile{Data: []byte("component \"bar\" {}\n")},
+	}
+
+	config, diags := LoadConfigFS(fsys, []string{"*.tfcomponents.hcl"})
+	if diags.HasErrors() {
+		t.Fatalf("unexpected errors: %s", diags.Err())
+	}
+	if got, want := len(config.Components), 2; got != want {
+		t.Fatalf("wrong number of merged components: got %d, want %d", got, want)
+	}
+	if got, want := len(config.Filenames), 2; got != want {
+		t.Fatalf("wrong number of merged filenames: got %d, want %d", got, want)
+	}
+}
+
+func TestLoadConfigFSMergeDuplicateName(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.tfcomponents.hcl": &fstest.MapFile{Data: []byte("variable \"foo\" {}\n")},
+		"b.tfcomponents.hcl": &fstest.MapFile{Data: []byte("variable \"foo\" {}\n")},
+	}
+
+	config, diags := LoadConfigFS(fsys, []string{"*.tfcomponents.hcl"})
+	if !diags.HasErrors() {
+		t.Fatalf("expected an error for the duplicate variable declaration, got none")
+	}
+	if got, want := len(config.InputVariables), 1; got != want {
+		t.Fatalf("wrong number of merged variables: got %d, want %d", got, want)
+	}
+}
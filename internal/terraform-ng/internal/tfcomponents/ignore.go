@@ -0,0 +1,84 @@
+package tfcomponents
+
+import (
+	"io/fs"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/hashicorp/terraform/internal/tfdiags"
+)
+
+// ignoreFilename is the name of the file, if present in the root of a
+// directory passed to LoadConfigDir or LoadConfigFS, whose contents are
+// interpreted as a list of doublestar glob patterns to exclude, one per
+// line, skipping blank lines and lines starting with "#". Each pattern is
+// matched against a file's full path only, so a pattern naming a directory
+// doesn't exclude the files inside it, and a line starting with "!" is not
+// treated as a negation.
+const ignoreFilename = ".terraformignore"
+
+// defaultIgnorePatterns are excluded unconditionally, regardless of
+// whether an ignore file is present, mirroring Terraform's own defaults
+// for directories that are never meaningful configuration sources.
+var defaultIgnorePatterns = []string{
+	".git/**",
+	".terraform/**",
+}
+
+// ignoreRules is a set of doublestar patterns identifying paths that
+// LoadConfigFS should skip even if they were matched by a caller-provided
+// pattern.
+type ignoreRules struct {
+	patterns []string
+}
+
+func (r ignoreRules) match(path string) bool {
+	for _, pattern := range r.patterns {
+		if ok, _ := doublestar.Match(pattern, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// loadIgnoreRules reads the ".terraformignore" file in the root of fsys, if
+// any, and combines its patterns with defaultIgnorePatterns. It's not an
+// error for the ignore file to be absent.
+func loadIgnoreRules(fsys fs.FS) (ignoreRules, tfdiags.Diagnostics) {
+	var diags tfdiags.Diagnostics
+	ret := ignoreRules{
+		patterns: append([]string(nil), defaultIgnorePatterns...),
+	}
+
+	src, err := fs.ReadFile(fsys, ignoreFilename)
+	if err != nil {
+		// Absence of an ignore file is the common case and not an error;
+		// any other error is unlikely enough that we just ignore it too,
+		// since failing to honor excludes isn't as serious as failing to
+		// load configuration altogether.
+		return ret, diags
+	}
+
+	for _, line := range strings.Split(string(src), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ret.patterns = append(ret.patterns, ignorePattern(line))
+	}
+
+	return ret, diags
+}
+
+// ignorePattern adapts a single line from a ".terraformignore" file into a
+// doublestar pattern. Following the same convention as ".gitignore", a
+// pattern containing no slash matches a path of that name at any depth,
+// not only at the root, so we prefix it with "**/" to get that behavior
+// out of doublestar, which otherwise treats every pattern as anchored.
+func ignorePattern(pattern string) string {
+	if strings.Contains(pattern, "/") {
+		return pattern
+	}
+	return "**/" + pattern
+}
@@ -0,0 +1,111 @@
+package tfcomponents
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path/filepath"
+
+	getter "github.com/hashicorp/go-getter"
+	hcl "github.com/hashicorp/hcl/v2"
+
+	"github.com/hashicorp/terraform/internal/tfdiags"
+)
+
+// componentGroupCacheDirName is the name of the subdirectory of a
+// configuration's ".terraform" directory that an Installer uses to cache
+// the installed contents of component_group sources.
+const componentGroupCacheDirName = "components"
+
+// Installer downloads the source code for component_group blocks that
+// declare a "source" argument, using github.com/hashicorp/go-getter to
+// support the same range of source address types as Terraform module
+// sources: git, HTTPS, S3, GCS, and local relative paths.
+type Installer struct {
+	// Getters overrides the set of go-getter protocol handlers used to
+	// fetch group sources. When nil, Install uses go-getter's own default
+	// set.
+	Getters map[string]getter.Getter
+}
+
+// NewInstaller returns an Installer configured with go-getter's default
+// protocol handlers.
+func NewInstaller() *Installer {
+	return &Installer{}
+}
+
+// Install downloads the source for group into a cache directory under
+// workDir, named after a hash of the group's source address and version,
+// and then loads the downloaded directory to populate group.Config.
+//
+// workDir is typically the ".terraform" directory belonging to the
+// configuration that declared group, used only as the root of the
+// installer's cache. declDir is the directory containing the file that
+// declared group, and is used to resolve relative local source addresses
+// such as "./sibling" the same way Terraform resolves relative module
+// sources: relative to the declaring file, not relative to workDir.
+//
+// On success, group.Dir and group.Config are both populated. Checksum
+// mismatches and network errors are reported as diagnostics blamed on
+// group's DeclRange.
+func (i *Installer) Install(ctx context.Context, workDir, declDir string, group *ComponentGroup) tfdiags.Diagnostics {
+	var diags tfdiags.Diagnostics
+
+	if group.SourceAddr == "" {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Missing component group source",
+			Detail:   fmt.Sprintf("Component group %q has no source address to install.", group.Name),
+			Subject:  group.DeclRange.ToHCL().Ptr(),
+		})
+		return diags
+	}
+
+	cacheDir := filepath.Join(workDir, componentGroupCacheDirName, installCacheKey(group.SourceAddr, group.Version))
+
+	client := &getter.Client{
+		Ctx:  ctx,
+		Src:  group.SourceAddr,
+		Dst:  cacheDir,
+		Pwd:  declDir,
+		Mode: getter.ClientModeDir,
+	}
+	if i.Getters != nil {
+		client.Getters = i.Getters
+	}
+
+	if err := client.Get(); err != nil {
+		diags = diags.Append(&hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to install component group",
+			Detail: fmt.Sprintf(
+				"Error while installing %q from %q: %s.",
+				group.Name, group.SourceAddr, err,
+			),
+			Subject: group.DeclRange.ToHCL().Ptr(),
+		})
+		return diags
+	}
+	group.Dir = cacheDir
+
+	config, moreDiags := LoadConfigDir(cacheDir, []string{"**/*.tfcomponents.hcl"})
+	diags = diags.Append(moreDiags)
+	if diags.HasErrors() {
+		return diags
+	}
+	group.Config = config
+
+	return diags
+}
+
+// installCacheKey returns a filesystem-safe cache key derived from a
+// group's source address and version, so that distinct sources don't
+// collide with each other in the installer's cache directory.
+func installCacheKey(sourceAddr, version string) string {
+	h := sha256.New()
+	h.Write([]byte(sourceAddr))
+	h.Write([]byte{0})
+	h.Write([]byte(version))
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,21 @@
+package tfcomponents
+
+import "testing"
+
+func TestInstallCacheKey(t *testing.T) {
+	const src = "git::https://example.com/foo.git"
+
+	a := installCacheKey(src, "")
+	b := installCacheKey(src, "v1.0.0")
+	c := installCacheKey("git::https://example.com/bar.git", "")
+
+	if a == b {
+		t.Errorf("expected different versions of the same source to produce different cache keys")
+	}
+	if a == c {
+		t.Errorf("expected different sources to produce different cache keys")
+	}
+	if got := installCacheKey(src, ""); got != a {
+		t.Errorf("installCacheKey is not deterministic: got %q, want %q", got, a)
+	}
+}
@@ -0,0 +1,99 @@
+package tfcomponents
+
+import (
+	"fmt"
+	"sort"
+
+	hcl "github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/hashicorp/terraform/internal/configs"
+)
+
+// decodeVariableBlock decodes a single "variable" block.
+//
+// Terraform's own configs.NewModule decodes these same blocks for "real"
+// modules, but does so using decodeVariableBlock, decodeLocalsBlock, and
+// decodeOutputBlock functions that are private to package configs, so we
+// can't call into them from here. We instead decode just enough to
+// identify each declaration for the components tree; evaluating a
+// variable's type constraint, default, and validation rules remains the
+// responsibility of whatever later evaluates the configuration.
+func decodeVariableBlock(block *hcl.Block) (*configs.Variable, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	name := block.Labels[0]
+	if !hclsyntax.ValidIdentifier(name) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid variable name",
+			Detail:   fmt.Sprintf("Variable name %q is not a valid identifier.", name),
+			Subject:  block.LabelRanges[0].Ptr(),
+		})
+	}
+
+	// We don't decode the variable's own arguments here, so we accept
+	// (and ignore) whatever attributes and nested blocks the body
+	// contains rather than raising spurious "unsupported argument"
+	// errors.
+	_, _, bodyDiags := block.Body.PartialContent(&hcl.BodySchema{})
+	diags = append(diags, bodyDiags...)
+
+	return &configs.Variable{
+		Name:      name,
+		DeclRange: block.DefRange,
+	}, diags
+}
+
+// decodeLocalsBlock decodes a single "locals" block, which declares zero or
+// more local values as its own arbitrary attributes.
+func decodeLocalsBlock(block *hcl.Block) ([]*configs.Local, hcl.Diagnostics) {
+	attrs, diags := block.Body.JustAttributes()
+
+	locals := make([]*configs.Local, 0, len(attrs))
+	for name, attr := range attrs {
+		locals = append(locals, &configs.Local{
+			Name:      name,
+			Expr:      attr.Expr,
+			DeclRange: attr.Range,
+		})
+	}
+	sort.Slice(locals, func(i, j int) bool { return locals[i].Name < locals[j].Name })
+
+	return locals, diags
+}
+
+// outputBlockSchema is the body schema for an individual "output" block.
+var outputBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "value", Required: true},
+	},
+}
+
+// decodeOutputBlock decodes a single "output" block.
+func decodeOutputBlock(block *hcl.Block) (*configs.Output, hcl.Diagnostics) {
+	var diags hcl.Diagnostics
+
+	name := block.Labels[0]
+	if !hclsyntax.ValidIdentifier(name) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid output name",
+			Detail:   fmt.Sprintf("Output name %q is not a valid identifier.", name),
+			Subject:  block.LabelRanges[0].Ptr(),
+		})
+	}
+
+	content, bodyDiags := block.Body.Content(outputBlockSchema)
+	diags = append(diags, bodyDiags...)
+
+	ret := &configs.Output{
+		Name:      name,
+		DeclRange: block.DefRange,
+	}
+	if attr, exists := content.Attributes["value"]; exists {
+		ret.Expr = attr.Expr
+	}
+
+	return ret, diags
+}